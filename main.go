@@ -14,10 +14,14 @@ type message struct {
 	Status int       `json:"status,omitempty"`
 	URL    string    `json:"url"`
 	Error  string    `json:"error,omitempty"`
+	Caller string    `json:"caller,omitempty"`
 }
 
 func main() {
-	log := logger.All().WithWriter(logger.NewJSONWriterFromEnv())
+	log := logger.All().
+		WithWriter(logger.NewJSONWriterFromEnv()).
+		WithCaller(true).
+		WithOverflowPolicy(logger.OverflowPolicyDropOldest)
 	urls := strings.Split(os.Getenv("URLS"), ",")
 	for _, url := range urls {
 		go func(url string) {
@@ -50,3 +54,26 @@ func (m message) Flag() logger.Flag {
 func (m message) Timestamp() time.Time {
 	return m.Time
 }
+
+func (m message) WithTime(t time.Time) logger.Event {
+	m.Time = t
+	return m
+}
+
+func (m message) WithCaller(caller string) logger.Event {
+	m.Caller = caller
+	return m
+}
+
+func (m message) Fields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"url": m.URL,
+	}
+	if m.Status != 0 {
+		fields["status"] = m.Status
+	}
+	if len(m.Error) > 0 {
+		fields["error"] = m.Error
+	}
+	return fields
+}
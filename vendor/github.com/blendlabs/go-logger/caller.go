@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// CallerMarshalFunc formats a captured caller site into its displayed form.
+// It is a package-level var so callers can shorten paths (e.g. trim a GOPATH
+// prefix) the same way they'd customize FormatTimestamp, mirroring the
+// zerolog customization pattern.
+var CallerMarshalFunc = func(pc uintptr, file string, line int) string {
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+// EventCaller is an interface events can implement to carry the call site
+// that produced them. Logger's trigger methods (Infof, Errorf, SyncFatalf,
+// Write, ...) capture this at write-time via runtime.Caller, before the
+// event enters the async Work channel, since the channel consumer runs on a
+// different goroutine where the original call site is no longer available.
+type EventCaller interface {
+	Caller() string
+}
+
+// EventCallerSettable is an interface events can implement to accept a
+// captured caller site, mirroring EventTimeOverridable's WithTime. Logger's
+// trigger methods use it to attach a captured caller site to arbitrary event
+// types, like main's message, without forcing every Event to carry caller
+// plumbing. Only events that also implement EventCaller expose that site
+// through Caller().
+type EventCallerSettable interface {
+	WithCaller(caller string) Event
+}
+
+// CaptureCaller captures the calling site `skip` frames up the stack and
+// formats it with CallerMarshalFunc. CaptureCaller's own frame is not
+// counted: skip=0 reports the line that called CaptureCaller, and a function
+// passing skip=1 captures its own caller.
+func CaptureCaller(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	return CallerMarshalFunc(pc, file, line)
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCaptureCallerSkipZeroReportsOwnCallSite(t *testing.T) {
+	capture := func() string { return CaptureCaller(0) }
+	caller := capture()
+	if !strings.Contains(caller, "caller_test.go") {
+		t.Fatalf("expected CaptureCaller(0) to report its direct caller, got %q", caller)
+	}
+}
+
+func TestCaptureCallerSkipOneReportsGrandparent(t *testing.T) {
+	// wrapper calls CaptureCaller(1), which should skip past wrapper itself
+	// and report this test function's call site instead.
+	wrapper := func() string { return CaptureCaller(1) }
+	caller := wrapper()
+	if !strings.Contains(caller, "caller_test.go") {
+		t.Fatalf("expected CaptureCaller(1) to report the wrapper's caller, got %q", caller)
+	}
+}
+
+func TestCaptureCallerInvalidSkipReturnsEmpty(t *testing.T) {
+	if caller := CaptureCaller(1000); caller != "" {
+		t.Fatalf("expected an out-of-range skip to return empty, got %q", caller)
+	}
+}
+
+// TestLoggerWriteAttachesCallerWhenEnabled guards against a prior class of
+// bug where caller capture was configured but never actually reached the
+// event: Write only attaches a caller site when WithCaller(true) was called
+// and the event implements EventCallerSettable. The worker is injected
+// unstarted (mirroring TestLoggerDispatchUsesWorkerEnqueue) so the event can
+// be read back off Work deterministically, without racing a consumer
+// goroutine.
+func TestLoggerWriteAttachesCallerWhenEnabled(t *testing.T) {
+	l := New(NewFlagSetAll()).WithCaller(true)
+	w := newTestWorker(1, OverflowPolicyDropNewest)
+	l.workers[Info] = []*Worker{w}
+
+	if err := l.Write(formattedEvent{EventFlag: Info, Message: "hi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e := <-w.Work
+	caller, ok := e.(EventCaller)
+	if !ok {
+		t.Fatalf("expected the written event to implement EventCaller, got %T", e)
+	}
+	if !strings.Contains(caller.Caller(), "caller_test.go") {
+		t.Fatalf("expected the captured caller to point at this test file, got %q", caller.Caller())
+	}
+}
+
+// TestLoggerWriteOmitsCallerWhenDisabled guards against the opposite bug:
+// a caller site leaking onto events when caller capture was never enabled.
+func TestLoggerWriteOmitsCallerWhenDisabled(t *testing.T) {
+	l := New(NewFlagSetAll())
+	w := newTestWorker(1, OverflowPolicyDropNewest)
+	l.workers[Info] = []*Worker{w}
+
+	if err := l.Write(formattedEvent{EventFlag: Info, Message: "hi"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e := <-w.Work
+	if caller := e.(EventCaller).Caller(); caller != "" {
+		t.Fatalf("expected no caller site when caller capture is disabled, got %q", caller)
+	}
+}
+
+// TestLoggerInfofCapturesCallerOfTriggerMethod guards against formatf's
+// skip depth being off by one: the captured site should be the test's call
+// to Infof, not formatf or Infof itself.
+func TestLoggerInfofCapturesCallerOfTriggerMethod(t *testing.T) {
+	l := New(NewFlagSetAll()).WithCaller(true)
+	w := newTestWorker(1, OverflowPolicyDropNewest)
+	l.workers[Info] = []*Worker{w}
+
+	if err := l.Infof("hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e := <-w.Work
+	caller := e.(EventCaller).Caller()
+	if !strings.Contains(caller, "caller_test.go") {
+		t.Fatalf("expected Infof's caller to point at this test file, got %q", caller)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Package cbor implements a minimal encoder for the subset of CBOR (RFC 7049)
+major types needed to represent logging events: maps, arrays, text strings,
+unsigned/negative integers, floats, and a handful of tags. It intentionally
+does not attempt to be a general purpose CBOR library; it exists so the
+logger can emit compact binary events without pulling in an external
+dependency.
+*/
+package cbor
+
+import (
+	"bytes"
+	"math"
+)
+
+// Major types, per RFC 7049 section 2.1.
+const (
+	majorUnsignedInt byte = 0
+	majorNegativeInt byte = 1
+	majorByteString  byte = 2
+	majorTextString  byte = 3
+	majorArray       byte = 4
+	majorMap         byte = 5
+	majorTag         byte = 6
+	majorSimple      byte = 7
+)
+
+const (
+	additionalUint8  byte = 24
+	additionalUint16 byte = 25
+	additionalUint32 byte = 26
+	additionalUint64 byte = 27
+
+	simpleFalse   byte = 20
+	simpleTrue    byte = 21
+	simpleNull    byte = 22
+	simpleFloat64 byte = 27
+)
+
+// TagTimestamp is the CBOR tag for an RFC 3339 encoded date/time string (tag 0).
+const TagTimestamp = 0
+
+// TagEpochTimestamp is the CBOR tag for a numeric epoch timestamp (tag 1).
+const TagEpochTimestamp = 1
+
+// NewEncoder returns a new Encoder that writes to the given buffer.
+func NewEncoder(buf *bytes.Buffer) *Encoder {
+	return &Encoder{buf: buf}
+}
+
+// Encoder writes CBOR-encoded values to an underlying buffer.
+type Encoder struct {
+	buf *bytes.Buffer
+}
+
+func (e *Encoder) writeTypeAndLength(major byte, length uint64) {
+	switch {
+	case length < uint64(additionalUint8):
+		e.buf.WriteByte(major<<5 | byte(length))
+	case length <= math.MaxUint8:
+		e.buf.WriteByte(major<<5 | additionalUint8)
+		e.buf.WriteByte(byte(length))
+	case length <= math.MaxUint16:
+		e.buf.WriteByte(major<<5 | additionalUint16)
+		e.buf.WriteByte(byte(length >> 8))
+		e.buf.WriteByte(byte(length))
+	case length <= math.MaxUint32:
+		e.buf.WriteByte(major<<5 | additionalUint32)
+		for shift := 24; shift >= 0; shift -= 8 {
+			e.buf.WriteByte(byte(length >> uint(shift)))
+		}
+	default:
+		e.buf.WriteByte(major<<5 | additionalUint64)
+		for shift := 56; shift >= 0; shift -= 8 {
+			e.buf.WriteByte(byte(length >> uint(shift)))
+		}
+	}
+}
+
+// EncodeMapHeader writes the header for a map with the given number of entries.
+// Callers must then write exactly `size` key/value pairs.
+func (e *Encoder) EncodeMapHeader(size int) {
+	e.writeTypeAndLength(majorMap, uint64(size))
+}
+
+// EncodeArrayHeader writes the header for an array with the given number of elements.
+// Callers must then write exactly `size` elements.
+func (e *Encoder) EncodeArrayHeader(size int) {
+	e.writeTypeAndLength(majorArray, uint64(size))
+}
+
+// EncodeTag writes a tag header; the tagged value must be written immediately after.
+func (e *Encoder) EncodeTag(tag uint64) {
+	e.writeTypeAndLength(majorTag, tag)
+}
+
+// EncodeText writes a UTF-8 text string.
+func (e *Encoder) EncodeText(value string) {
+	e.writeTypeAndLength(majorTextString, uint64(len(value)))
+	e.buf.WriteString(value)
+}
+
+// EncodeBytes writes a byte string.
+func (e *Encoder) EncodeBytes(value []byte) {
+	e.writeTypeAndLength(majorByteString, uint64(len(value)))
+	e.buf.Write(value)
+}
+
+// EncodeInt writes a signed integer, choosing the unsigned or negative major
+// type as appropriate.
+func (e *Encoder) EncodeInt(value int64) {
+	if value >= 0 {
+		e.writeTypeAndLength(majorUnsignedInt, uint64(value))
+		return
+	}
+	e.writeTypeAndLength(majorNegativeInt, uint64(-(value + 1)))
+}
+
+// EncodeUint writes an unsigned integer.
+func (e *Encoder) EncodeUint(value uint64) {
+	e.writeTypeAndLength(majorUnsignedInt, value)
+}
+
+// EncodeFloat64 writes a double-precision float.
+func (e *Encoder) EncodeFloat64(value float64) {
+	e.buf.WriteByte(majorSimple<<5 | simpleFloat64)
+	bits := math.Float64bits(value)
+	for shift := 56; shift >= 0; shift -= 8 {
+		e.buf.WriteByte(byte(bits >> uint(shift)))
+	}
+}
+
+// EncodeBool writes a boolean simple value.
+func (e *Encoder) EncodeBool(value bool) {
+	if value {
+		e.buf.WriteByte(majorSimple<<5 | simpleTrue)
+		return
+	}
+	e.buf.WriteByte(majorSimple<<5 | simpleFalse)
+}
+
+// EncodeNil writes the CBOR null simple value.
+func (e *Encoder) EncodeNil() {
+	e.buf.WriteByte(majorSimple<<5 | simpleNull)
+}
+
+// Encode writes a best-effort encoding of an arbitrary Go value, used as the
+// reflection-based fallback for values that don't implement a Marshaler.
+func (e *Encoder) Encode(value interface{}) {
+	switch typed := value.(type) {
+	case nil:
+		e.EncodeNil()
+	case bool:
+		e.EncodeBool(typed)
+	case string:
+		e.EncodeText(typed)
+	case []byte:
+		e.EncodeBytes(typed)
+	case int:
+		e.EncodeInt(int64(typed))
+	case int64:
+		e.EncodeInt(typed)
+	case uint64:
+		e.EncodeUint(typed)
+	case float64:
+		e.EncodeFloat64(typed)
+	case map[string]interface{}:
+		e.EncodeMapHeader(len(typed))
+		for key, val := range typed {
+			e.EncodeText(key)
+			e.Encode(val)
+		}
+	case []interface{}:
+		e.EncodeArrayHeader(len(typed))
+		for _, val := range typed {
+			e.Encode(val)
+		}
+	default:
+		e.encodeReflect(value)
+	}
+}
@@ -0,0 +1,95 @@
+package cbor
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func encode(t *testing.T, value interface{}) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	NewEncoder(buf).Encode(value)
+	return buf.Bytes()
+}
+
+func TestEncodeInt(t *testing.T) {
+	if got := encode(t, 10); !bytes.Equal(got, []byte{0x0a}) {
+		t.Fatalf("expected a single-byte unsigned int, got % x", got)
+	}
+	if got := encode(t, -1); !bytes.Equal(got, []byte{0x20}) {
+		t.Fatalf("expected a single-byte negative int, got % x", got)
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	got := encode(t, "hi")
+	want := []byte{0x62, 'h', 'i'} // major 3 (text), length 2
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected %x, got %x", want, got)
+	}
+}
+
+func TestEncodeTopLevelBytes(t *testing.T) {
+	got := encode(t, []byte{1, 2, 3})
+	want := []byte{0x43, 1, 2, 3} // major 2 (byte string), length 3
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected %x, got %x", want, got)
+	}
+}
+
+// TestEncodeReflectedByteSliceField guards against a prior bug where the
+// reflection fallback (used for struct fields) had no []byte fast path,
+// unlike the top-level Encode switch, so a []byte field was encoded as a
+// CBOR array of per-byte integers (majorArray) instead of a byte string
+// (majorByteString) — multiplying size for exactly the binary payloads
+// (hashes, raw bodies) this encoder exists to shrink.
+func TestEncodeReflectedByteSliceField(t *testing.T) {
+	type withBlob struct {
+		Blob []byte
+	}
+	buf := new(bytes.Buffer)
+	NewEncoder(buf).Encode(withBlob{Blob: []byte{1, 2, 3}})
+	got := buf.Bytes()
+
+	// map{1 pair} -> text("Blob") -> byte string[1,2,3]
+	want := []byte{0xa1, 0x64, 'B', 'l', 'o', 'b', 0x43, 1, 2, 3}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected reflected []byte field to encode as a byte string %x, got %x", want, got)
+	}
+}
+
+func TestEncodeReflectedIntSliceField(t *testing.T) {
+	type withInts struct {
+		Nums []int
+	}
+	buf := new(bytes.Buffer)
+	NewEncoder(buf).Encode(withInts{Nums: []int{1, 2}})
+	got := buf.Bytes()
+
+	// map{1 pair} -> text("Nums") -> array[1, 2]
+	want := []byte{0xa1, 0x64, 'N', 'u', 'm', 's', 0x82, 0x01, 0x02}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected a non-byte slice to still encode as an array %x, got %x", want, got)
+	}
+}
+
+func TestEncodeStructWithExtra(t *testing.T) {
+	type payload struct {
+		Name string
+	}
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	v := reflect.ValueOf(payload{Name: "a"})
+	enc.EncodeStructWithExtra(v, 1, func() {
+		enc.EncodeText("flag")
+		enc.EncodeText("info")
+	})
+	got := buf.Bytes()
+
+	// map{2 pairs} -> text("flag") -> text("info") -> text("Name") -> text("a")
+	want := []byte{0xa2, 0x64, 'f', 'l', 'a', 'g', 0x64, 'i', 'n', 'f', 'o', 0x64, 'N', 'a', 'm', 'e', 0x61, 'a'}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected %x, got %x", want, got)
+	}
+}
@@ -0,0 +1,99 @@
+package cbor
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// encodeReflect encodes a value of unknown concrete type by walking its
+// reflected shape. It is the fallback used by Encode for types that aren't
+// one of the common concrete cases (structs, slices of a named element
+// type, pointers, etc).
+func (e *Encoder) encodeReflect(value interface{}) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		e.EncodeNil()
+		return
+	}
+	e.encodeReflectValue(v)
+}
+
+func (e *Encoder) encodeReflectValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			e.EncodeNil()
+			return
+		}
+		e.encodeReflectValue(v.Elem())
+	case reflect.Struct:
+		e.encodeReflectStruct(v)
+	case reflect.Map:
+		keys := v.MapKeys()
+		e.EncodeMapHeader(len(keys))
+		for _, key := range keys {
+			if key.Kind() == reflect.String {
+				e.EncodeText(key.String())
+			} else {
+				e.EncodeText(fmt.Sprintf("%v", key.Interface()))
+			}
+			e.encodeReflectValue(v.MapIndex(key))
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			e.EncodeBytes(v.Bytes())
+			return
+		}
+		e.EncodeArrayHeader(v.Len())
+		for i := 0; i < v.Len(); i++ {
+			e.encodeReflectValue(v.Index(i))
+		}
+	case reflect.String:
+		e.EncodeText(v.String())
+	case reflect.Bool:
+		e.EncodeBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.EncodeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		e.EncodeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.EncodeFloat64(v.Float())
+	default:
+		e.EncodeNil()
+	}
+}
+
+// encodeReflectStruct encodes the exported fields of a struct as a map,
+// keyed by field name.
+func (e *Encoder) encodeReflectStruct(v reflect.Value) {
+	e.EncodeStructWithExtra(v, 0, nil)
+}
+
+// EncodeStructWithExtra encodes a struct's exported fields as a map, the
+// same way the reflection fallback in Encode does, but prefixed with
+// `extraPairs` additional key/value pairs written by `writeExtra` before the
+// struct's own fields. This lets callers that need to graft synthetic keys
+// (e.g. a logger.Event's Flag()/Timestamp()) onto a reflected struct
+// encoding reuse the same exported-field walk instead of reimplementing it.
+func (e *Encoder) EncodeStructWithExtra(v reflect.Value, extraPairs int, writeExtra func()) {
+	exported := exportedFields(v)
+	e.EncodeMapHeader(extraPairs + len(exported))
+	if writeExtra != nil {
+		writeExtra()
+	}
+	for _, i := range exported {
+		e.EncodeText(v.Type().Field(i).Name)
+		e.encodeReflectValue(v.Field(i))
+	}
+}
+
+func exportedFields(v reflect.Value) []int {
+	t := v.Type()
+	var exported []int
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			exported = append(exported, i)
+		}
+	}
+	return exported
+}
@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/blendlabs/go-logger/cbor"
+	"github.com/blendlabs/go-util/env"
+)
+
+// EnvVarBinaryLog is the environment variable that selects a CBORWriter over
+// the default JSONWriter when set via WithBinaryLog.
+const EnvVarBinaryLog = "LOG_BINARY"
+
+// CBORMarshaler is an optional interface events can implement to supply a
+// hand-rolled CBOR encoding instead of falling back to reflection.
+type CBORMarshaler interface {
+	MarshalCBOR(enc *cbor.Encoder) error
+}
+
+// NewCBORWriter returns a new CBOR writer for an output.
+func NewCBORWriter(output io.Writer) *CBORWriter {
+	return &CBORWriter{
+		output:     NewInterlockedWriter(output),
+		bufferPool: NewBufferPool(DefaultBufferPoolSize),
+	}
+}
+
+// NewCBORWriterFromEnv returns a new CBOR writer from the environment.
+func NewCBORWriterFromEnv() *CBORWriter {
+	return &CBORWriter{
+		output:      NewInterlockedWriter(os.Stdout),
+		errorOutput: NewInterlockedWriter(os.Stderr),
+		bufferPool:  NewBufferPool(DefaultBufferPoolSize),
+		label:       env.Env().String(EnvVarLabel),
+	}
+}
+
+// WithBinaryLog selects a CBORWriter instead of the default JSONWriter based
+// on the `LOG_BINARY` environment variable, falling back to JSON otherwise.
+// This mirrors NewJSONWriterFromEnv/NewTextWriterFromEnv as the writer
+// constructor users wire into `logger.All().WithWriter(...)`.
+func WithBinaryLog() Writer {
+	if env.Env().Bool(EnvVarBinaryLog) {
+		return NewCBORWriterFromEnv()
+	}
+	return NewJSONWriterFromEnv()
+}
+
+// CBORWriter handles outputting logging events as CBOR (RFC 7049) binary
+// records, for compact, fast log shipping to collectors.
+type CBORWriter struct {
+	output      io.Writer
+	errorOutput io.Writer
+	label       string
+
+	bufferPool *BufferPool
+}
+
+// Output returns the output.
+func (wr *CBORWriter) Output() io.Writer {
+	return wr.output
+}
+
+// WithOutput sets the primary output.
+func (wr *CBORWriter) WithOutput(output io.Writer) *CBORWriter {
+	wr.output = NewInterlockedWriter(output)
+	return wr
+}
+
+// ErrorOutput returns an io.Writer for the error stream.
+func (wr *CBORWriter) ErrorOutput() io.Writer {
+	if wr.errorOutput != nil {
+		return wr.errorOutput
+	}
+	return wr.output
+}
+
+// WithErrorOutput sets the error output.
+func (wr *CBORWriter) WithErrorOutput(errorOutput io.Writer) *CBORWriter {
+	wr.errorOutput = NewInterlockedWriter(errorOutput)
+	return wr
+}
+
+// Write writes to the primary output.
+func (wr *CBORWriter) Write(e Event) error {
+	return wr.write(wr.Output(), e)
+}
+
+// WriteError writes to the error output (or the primary output if unset).
+func (wr *CBORWriter) WriteError(e Event) error {
+	return wr.write(wr.ErrorOutput(), e)
+}
+
+func (wr *CBORWriter) write(output io.Writer, e Event) error {
+	buf := wr.bufferPool.Get()
+	defer wr.bufferPool.Put(buf)
+
+	enc := cbor.NewEncoder(buf)
+	if typed, isTyped := e.(CBORMarshaler); isTyped {
+		if err := typed.MarshalCBOR(enc); err != nil {
+			return err
+		}
+	} else {
+		wr.encodeReflected(enc, e)
+	}
+
+	_, err := buf.WriteTo(output)
+	return err
+}
+
+// encodeReflected falls back to reflecting over the exported fields of the
+// event's concrete type, plus its Flag() and Timestamp(), when the event
+// does not implement CBORMarshaler itself. It delegates the actual
+// exported-field walk to cbor.Encoder.EncodeStructWithExtra, rather than
+// reimplementing it, and just supplies the extra flag/timestamp/label keys.
+func (wr *CBORWriter) encodeReflected(enc *cbor.Encoder, e Event) {
+	extraPairs := 2
+	if len(wr.label) > 0 {
+		extraPairs++
+	}
+	writeExtra := func() {
+		enc.EncodeText("flag")
+		enc.EncodeText(string(e.Flag()))
+		enc.EncodeText("timestamp")
+		enc.EncodeText(e.Timestamp().Format(DefaultTextTimeFormat))
+		if len(wr.label) > 0 {
+			enc.EncodeText("label")
+			enc.EncodeText(wr.label)
+		}
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(e))
+	if v.Kind() != reflect.Struct {
+		enc.EncodeMapHeader(extraPairs)
+		writeExtra()
+		return
+	}
+	enc.EncodeStructWithExtra(v, extraPairs, writeExtra)
+}
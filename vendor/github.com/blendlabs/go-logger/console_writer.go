@@ -0,0 +1,330 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultConsoleTimeFormat is the default time format for the console writer.
+	DefaultConsoleTimeFormat = time.RFC3339
+
+	// DefaultConsoleUseColor is a default setting for the console writer.
+	DefaultConsoleUseColor = true
+)
+
+// DefaultConsolePartsOrder is the default order parts are rendered in by the console writer.
+var DefaultConsolePartsOrder = []string{"time", "level", "label", "caller", "message"}
+
+// ConsoleEvent is the wire shape a ConsoleWriter reads from a JSONWriter stream.
+// Any fields not claimed by a part in PartsOrder are rendered alphabetically
+// as `key=value` pairs.
+type ConsoleEvent map[string]interface{}
+
+// NewConsoleWriter returns a new console writer that reads JSON-encoded events
+// from `input` and renders them as human-friendly, colorized columns to `output`.
+func NewConsoleWriter(input io.Reader, output io.Writer) *ConsoleWriter {
+	return &ConsoleWriter{
+		input:               input,
+		output:              NewInterlockedWriter(output),
+		useColor:            DefaultConsoleUseColor,
+		timeFormat:          DefaultConsoleTimeFormat,
+		partsOrder:          DefaultConsolePartsOrder,
+		bufferPool:          NewBufferPool(DefaultBufferPoolSize),
+		FormatTimestamp:     defaultFormatTimestamp,
+		FormatLevel:         defaultFormatLevel,
+		FormatLabel:         defaultFormatLabel,
+		FormatCaller:        defaultFormatCaller,
+		FormatMessage:       defaultFormatMessage,
+		FormatFieldName:     defaultFormatFieldName,
+		FormatFieldValue:    defaultFormatFieldValue,
+		FormatErrFieldName:  defaultFormatErrFieldName,
+		FormatErrFieldValue: defaultFormatErrFieldValue,
+	}
+}
+
+// NewConsoleWriterFromEnv returns a console writer reading from stdin and
+// writing pretty output to stdout, suitable for piping `myapp | logger-console`.
+func NewConsoleWriterFromEnv() *ConsoleWriter {
+	return NewConsoleWriter(os.Stdin, os.Stdout)
+}
+
+// ConsoleWriter consumes structured JSON-encoded events and re-renders them as
+// human-friendly, colorized columns for TTYs. It decouples the wire format
+// (JSONWriter) from the display format, so a single event stream can be
+// written to disk or shipped to a collector and also tailed by a human.
+type ConsoleWriter struct {
+	input       io.Reader
+	output      io.Writer
+	errorOutput io.Writer
+
+	noColor    bool
+	useColor   bool
+	timeFormat string
+	partsOrder []string
+
+	bufferPool *BufferPool
+
+	// FormatTimestamp formats the `time` part.
+	FormatTimestamp func(wr *ConsoleWriter, value interface{}) string
+	// FormatLevel formats the `level` part.
+	FormatLevel func(wr *ConsoleWriter, value interface{}) string
+	// FormatLabel formats the `label` part.
+	FormatLabel func(wr *ConsoleWriter, value interface{}) string
+	// FormatCaller formats the `caller` part.
+	FormatCaller func(wr *ConsoleWriter, value interface{}) string
+	// FormatMessage formats the `message` part.
+	FormatMessage func(wr *ConsoleWriter, value interface{}) string
+	// FormatFieldName formats the key of a non-error remainder field.
+	FormatFieldName func(wr *ConsoleWriter, name string) string
+	// FormatFieldValue formats the value of a non-error remainder field.
+	FormatFieldValue func(wr *ConsoleWriter, value interface{}) string
+	// FormatErrFieldName formats the key of an error remainder field.
+	FormatErrFieldName func(wr *ConsoleWriter, name string) string
+	// FormatErrFieldValue formats the value of an error remainder field.
+	FormatErrFieldValue func(wr *ConsoleWriter, value interface{}) string
+}
+
+// NoColor returns the no color option.
+func (cw *ConsoleWriter) NoColor() bool {
+	return cw.noColor
+}
+
+// WithNoColor sets the no color option.
+func (cw *ConsoleWriter) WithNoColor(noColor bool) *ConsoleWriter {
+	cw.noColor = noColor
+	cw.useColor = !noColor
+	return cw
+}
+
+// TimeFormat returns the time format.
+func (cw *ConsoleWriter) TimeFormat() string {
+	return cw.timeFormat
+}
+
+// WithTimeFormat sets the time format.
+func (cw *ConsoleWriter) WithTimeFormat(timeFormat string) *ConsoleWriter {
+	cw.timeFormat = timeFormat
+	return cw
+}
+
+// PartsOrder returns the part render order.
+func (cw *ConsoleWriter) PartsOrder() []string {
+	return cw.partsOrder
+}
+
+// WithPartsOrder sets the part render order. Parts not present in the
+// underlying event are skipped; parts not named here are appended
+// alphabetically after the known parts.
+func (cw *ConsoleWriter) WithPartsOrder(partsOrder []string) *ConsoleWriter {
+	cw.partsOrder = partsOrder
+	return cw
+}
+
+// Output returns the output.
+func (cw *ConsoleWriter) Output() io.Writer {
+	return cw.output
+}
+
+// WithOutput sets the primary output.
+func (cw *ConsoleWriter) WithOutput(output io.Writer) *ConsoleWriter {
+	cw.output = NewInterlockedWriter(output)
+	return cw
+}
+
+// ErrorOutput returns an io.Writer for the error stream.
+func (cw *ConsoleWriter) ErrorOutput() io.Writer {
+	if cw.errorOutput != nil {
+		return cw.errorOutput
+	}
+	return cw.output
+}
+
+// WithErrorOutput sets the error output.
+func (cw *ConsoleWriter) WithErrorOutput(errorOutput io.Writer) *ConsoleWriter {
+	cw.errorOutput = NewInterlockedWriter(errorOutput)
+	return cw
+}
+
+// Colorize (optionally) applies a color to a string.
+func (cw *ConsoleWriter) Colorize(value string, color AnsiColor) string {
+	if cw.useColor && !cw.noColor {
+		return color.Apply(value)
+	}
+	return value
+}
+
+// Write renders a single Event directly to the output, bypassing the JSON
+// wire format entirely. Unlike a plain json.Marshal round-trip, this reads
+// Flag()/Timestamp() (and EventCaller/EventFields, if implemented) straight
+// off the Event, since those are Go methods, not JSON object keys, and
+// would otherwise be silently dropped by an encode/decode round-trip.
+func (cw *ConsoleWriter) Write(e Event) error {
+	return cw.write(cw.Output(), e)
+}
+
+// WriteError renders a single Event to the error output (or the primary
+// output, if unset).
+func (cw *ConsoleWriter) WriteError(e Event) error {
+	return cw.write(cw.ErrorOutput(), e)
+}
+
+func (cw *ConsoleWriter) write(output io.Writer, e Event) error {
+	buf := cw.bufferPool.Get()
+	defer cw.bufferPool.Put(buf)
+
+	return cw.writeConsoleEvent(output, buf, cw.toConsoleEvent(e))
+}
+
+// toConsoleEvent builds a ConsoleEvent directly from an Event's Go-level
+// methods, rather than round-tripping it through JSON.
+func (cw *ConsoleWriter) toConsoleEvent(e Event) ConsoleEvent {
+	fields := ConsoleEvent{
+		"level": string(e.Flag()),
+		"time":  e.Timestamp().UTC().Format(DefaultConsoleTimeFormat),
+	}
+	if typed, isTyped := e.(EventCaller); isTyped {
+		if caller := typed.Caller(); len(caller) > 0 {
+			fields["caller"] = caller
+		}
+	}
+	if typed, isTyped := e.(fmt.Stringer); isTyped {
+		fields["message"] = typed.String()
+	}
+	if typed, isTyped := e.(EventFields); isTyped {
+		for key, value := range typed.Fields() {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// Process reads newline-delimited JSON events from Input until EOF or error,
+// rendering each as a pretty console line to Output.
+func (cw *ConsoleWriter) Process() error {
+	decoder := json.NewDecoder(cw.input)
+	for decoder.More() {
+		var fields ConsoleEvent
+		if err := decoder.Decode(&fields); err != nil {
+			return err
+		}
+		buf := cw.bufferPool.Get()
+		if err := cw.writeConsoleEvent(cw.Output(), buf, fields); err != nil {
+			cw.bufferPool.Put(buf)
+			return err
+		}
+		cw.bufferPool.Put(buf)
+	}
+	return nil
+}
+
+func (cw *ConsoleWriter) writeConsoleEvent(output io.Writer, buf *bytes.Buffer, fields ConsoleEvent) error {
+	rendered := make(map[string]bool, len(fields))
+
+	for _, part := range cw.partsOrder {
+		value, ok := fields[part]
+		if !ok {
+			continue
+		}
+		rendered[part] = true
+
+		switch part {
+		case "time":
+			buf.WriteString(cw.FormatTimestamp(cw, value))
+		case "level":
+			buf.WriteString(cw.FormatLevel(cw, value))
+		case "label":
+			buf.WriteString(cw.FormatLabel(cw, value))
+		case "caller":
+			buf.WriteString(cw.FormatCaller(cw, value))
+		case "message":
+			buf.WriteString(cw.FormatMessage(cw, value))
+		default:
+			buf.WriteString(cw.FormatFieldName(cw, part))
+			buf.WriteRune('=')
+			buf.WriteString(cw.FormatFieldValue(cw, value))
+		}
+		buf.WriteRune(RuneSpace)
+	}
+
+	var remaining []string
+	for key := range fields {
+		if !rendered[key] {
+			remaining = append(remaining, key)
+		}
+	}
+	sort.Strings(remaining)
+
+	for _, key := range remaining {
+		if isErrFieldName(key) {
+			buf.WriteString(cw.FormatErrFieldName(cw, key))
+			buf.WriteRune('=')
+			buf.WriteString(cw.FormatErrFieldValue(cw, fields[key]))
+		} else {
+			buf.WriteString(cw.FormatFieldName(cw, key))
+			buf.WriteRune('=')
+			buf.WriteString(cw.FormatFieldValue(cw, fields[key]))
+		}
+		buf.WriteRune(RuneSpace)
+	}
+
+	buf.WriteRune(RuneNewline)
+	_, err := buf.WriteTo(output)
+	return err
+}
+
+func isErrFieldName(key string) bool {
+	return key == "error" || key == "err"
+}
+
+func defaultFormatTimestamp(wr *ConsoleWriter, value interface{}) string {
+	timeFormat := wr.timeFormat
+	if len(timeFormat) == 0 {
+		timeFormat = DefaultConsoleTimeFormat
+	}
+	if typed, ok := value.(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, typed); err == nil {
+			return wr.Colorize(parsed.Format(timeFormat), ColorGray)
+		}
+		return wr.Colorize(typed, ColorGray)
+	}
+	return wr.Colorize(fmt.Sprintf("%v", value), ColorGray)
+}
+
+func defaultFormatLevel(wr *ConsoleWriter, value interface{}) string {
+	flag := Flag(fmt.Sprintf("%v", value))
+	return fmt.Sprintf("[%s]", wr.Colorize(string(flag), GetFlagTextColor(flag)))
+}
+
+func defaultFormatLabel(wr *ConsoleWriter, value interface{}) string {
+	return fmt.Sprintf("[%s]", wr.Colorize(fmt.Sprintf("%v", value), ColorBlue))
+}
+
+func defaultFormatCaller(wr *ConsoleWriter, value interface{}) string {
+	return wr.Colorize(fmt.Sprintf("%v", value), ColorGray)
+}
+
+func defaultFormatMessage(wr *ConsoleWriter, value interface{}) string {
+	return fmt.Sprintf("%v", value)
+}
+
+func defaultFormatFieldName(wr *ConsoleWriter, name string) string {
+	return wr.Colorize(name, ColorGray)
+}
+
+func defaultFormatFieldValue(wr *ConsoleWriter, value interface{}) string {
+	return fmt.Sprintf("%v", value)
+}
+
+func defaultFormatErrFieldName(wr *ConsoleWriter, name string) string {
+	return wr.Colorize(name, ColorRed)
+}
+
+func defaultFormatErrFieldValue(wr *ConsoleWriter, value interface{}) string {
+	return wr.Colorize(fmt.Sprintf("%v", value), ColorRed)
+}
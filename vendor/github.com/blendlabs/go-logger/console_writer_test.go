@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type consoleTestEvent struct {
+	flag    Flag
+	time    time.Time
+	message string
+	caller  string
+	fields  map[string]interface{}
+}
+
+func (e consoleTestEvent) Flag() Flag           { return e.flag }
+func (e consoleTestEvent) Timestamp() time.Time { return e.time }
+func (e consoleTestEvent) String() string       { return e.message }
+func (e consoleTestEvent) Caller() string       { return e.caller }
+func (e consoleTestEvent) Fields() map[string]interface{} {
+	return e.fields
+}
+
+func TestConsoleWriterWriteRendersEventMethods(t *testing.T) {
+	out := new(bytes.Buffer)
+	cw := NewConsoleWriter(nil, out).WithNoColor(true)
+
+	e := consoleTestEvent{
+		flag:    "info",
+		time:    time.Now(),
+		message: "hello world",
+		caller:  "main.go:10",
+	}
+	if err := cw.Write(e); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	line := out.String()
+	for _, want := range []string{"[info]", "hello world", "main.go:10"} {
+		if !strings.Contains(line, want) {
+			t.Fatalf("expected rendered line to contain %q, got %q", want, line)
+		}
+	}
+}
+
+func TestConsoleWriterWriteRendersRemainderFields(t *testing.T) {
+	out := new(bytes.Buffer)
+	cw := NewConsoleWriter(nil, out).WithNoColor(true)
+
+	e := consoleTestEvent{
+		flag:    "info",
+		time:    time.Now(),
+		message: "ping",
+		fields:  map[string]interface{}{"url": "http://example.com", "error": "boom"},
+	}
+	if err := cw.Write(e); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	line := out.String()
+	for _, want := range []string{"url=http://example.com", "error=boom"} {
+		if !strings.Contains(line, want) {
+			t.Fatalf("expected rendered line to contain %q, got %q", want, line)
+		}
+	}
+}
+
+func TestConsoleWriterWriteErrorUsesErrorOutput(t *testing.T) {
+	out := new(bytes.Buffer)
+	errOut := new(bytes.Buffer)
+	cw := NewConsoleWriter(nil, out).WithErrorOutput(errOut).WithNoColor(true)
+
+	e := consoleTestEvent{flag: FlagFatal, time: time.Now(), message: "boom"}
+	if err := cw.WriteError(e); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out.Len() != 0 {
+		t.Fatalf("expected nothing written to the primary output, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "boom") {
+		t.Fatalf("expected the error output to contain the event, got %q", errOut.String())
+	}
+}
+
+func TestConsoleWriterProcessRendersJSONStream(t *testing.T) {
+	input := strings.NewReader(`{"level":"info","time":"2024-01-01T00:00:00Z","message":"from json"}`)
+	out := new(bytes.Buffer)
+	cw := NewConsoleWriter(input, out).WithNoColor(true)
+
+	if err := cw.Process(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "from json") {
+		t.Fatalf("expected Process to render the decoded event, got %q", out.String())
+	}
+}
@@ -0,0 +1,74 @@
+package logger
+
+import "time"
+
+// Hook is a synchronous event sink, fired before an event is dispatched to
+// the async listener workers. It lets integrations (Sentry, syslog, metrics
+// counters) observe every event of a given set of flags without each one
+// reinventing a Listener around the buffered Work channel.
+type Hook interface {
+	// Levels returns the flags this hook wants to be fired for.
+	Levels() []Flag
+	// Fire is called synchronously with the event, on the goroutine that
+	// triggered it. Implementations should not block for long.
+	Fire(Event) error
+}
+
+// EventFields is an interface events can implement to expose additional
+// structured data beyond Flag()/Timestamp(). TextWriter.write appends these
+// as `key=value` pairs after the message, and ConsoleWriter renders them as
+// remainder fields; CBORWriter does not read them, and encodes the event's
+// exported struct fields instead.
+type EventFields interface {
+	Fields() map[string]interface{}
+}
+
+// EventTimeOverridable is an interface events can implement to be backdated,
+// e.g. by a caller that replays or batches events. WithTime returns an Event
+// stamped with the given time, so Timestamp() reflects it, replacing the
+// bespoke per-type Timestamp() overrides callers write today.
+type EventTimeOverridable interface {
+	WithTime(t time.Time) Event
+}
+
+// NewHookSet returns a new, empty hook set.
+func NewHookSet() *HookSet {
+	return &HookSet{}
+}
+
+// HookSet manages a collection of hooks and fires them synchronously for a
+// given event. A Logger embeds a *HookSet and exposes it via AddHook so
+// callers can register hooks the same way they add listeners.
+type HookSet struct {
+	hooks []Hook
+}
+
+// Add registers a hook.
+func (hs *HookSet) Add(hook Hook) {
+	hs.hooks = append(hs.hooks, hook)
+}
+
+// Fire synchronously calls every hook registered for the event's flag,
+// returning the first error encountered, if any. It does not stop on error;
+// all matching hooks are fired regardless.
+func (hs *HookSet) Fire(e Event) error {
+	var err error
+	for _, hook := range hs.hooks {
+		if !hookAppliesTo(hook, e.Flag()) {
+			continue
+		}
+		if fireErr := hook.Fire(e); fireErr != nil && err == nil {
+			err = fireErr
+		}
+	}
+	return err
+}
+
+func hookAppliesTo(hook Hook, flag Flag) bool {
+	for _, level := range hook.Levels() {
+		if level == flag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+type testHook struct {
+	levels []Flag
+	fired  []Event
+	err    error
+}
+
+func (h *testHook) Levels() []Flag { return h.levels }
+func (h *testHook) Fire(e Event) error {
+	h.fired = append(h.fired, e)
+	return h.err
+}
+
+func TestHookSetFireOnlyAppliesMatchingLevels(t *testing.T) {
+	hs := NewHookSet()
+	pingHook := &testHook{levels: []Flag{"ping"}}
+	fatalHook := &testHook{levels: []Flag{FlagFatal}}
+	hs.Add(pingHook)
+	hs.Add(fatalHook)
+
+	hs.Fire(testEvent("ping"))
+
+	if len(pingHook.fired) != 1 {
+		t.Fatalf("expected the ping hook to fire once, got %d", len(pingHook.fired))
+	}
+	if len(fatalHook.fired) != 0 {
+		t.Fatalf("expected the fatal hook not to fire for a ping event, got %d", len(fatalHook.fired))
+	}
+}
+
+func TestHookSetFireReturnsFirstError(t *testing.T) {
+	hs := NewHookSet()
+	errA := &testHook{levels: []Flag{"ping"}, err: errTest("a")}
+	errB := &testHook{levels: []Flag{"ping"}, err: errTest("b")}
+	hs.Add(errA)
+	hs.Add(errB)
+
+	err := hs.Fire(testEvent("ping"))
+	if err == nil || err.Error() != "a" {
+		t.Fatalf("expected the first hook's error, got %v", err)
+	}
+	if len(errB.fired) != 1 {
+		t.Fatal("expected later hooks to still fire after an earlier one errors")
+	}
+}
+
+type errTest string
+
+func (e errTest) Error() string { return string(e) }
+
+func TestLoggerAddHookFiresOnDispatch(t *testing.T) {
+	l := New(NewFlagSetAll())
+	hook := &testHook{levels: []Flag{"ping"}}
+	l.AddHook(hook)
+
+	if err := l.dispatch(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hook.fired) != 1 {
+		t.Fatalf("expected AddHook to wire into dispatch, got %d fires", len(hook.fired))
+	}
+}
+
+// TestLoggerSyncFatalfFiresHooks guards against a prior bug where
+// SyncFatalf wrote straight to writers, skipping the hook subsystem
+// entirely — exactly the panic-recovery path (Worker.Process) that a
+// Sentry-style hook most needs to observe.
+func TestLoggerSyncFatalfFiresHooks(t *testing.T) {
+	l := New(NewFlagSetAll())
+	hook := &testHook{levels: []Flag{FlagFatal}}
+	l.AddHook(hook)
+
+	l.SyncFatalf("boom")
+
+	if len(hook.fired) != 1 {
+		t.Fatalf("expected SyncFatalf to fire hooks registered for FlagFatal, got %d fires", len(hook.fired))
+	}
+	if hook.fired[0].Flag() != FlagFatal {
+		t.Fatalf("expected the fired event's flag to be %q, got %q", FlagFatal, hook.fired[0].Flag())
+	}
+}
+
+type fieldsEvent struct {
+	flag Flag
+	time time.Time
+}
+
+func (e fieldsEvent) Flag() Flag           { return e.flag }
+func (e fieldsEvent) Timestamp() time.Time { return e.time }
+func (e fieldsEvent) Fields() map[string]interface{} {
+	return map[string]interface{}{"key": "value"}
+}
+func (e fieldsEvent) WithTime(t time.Time) Event {
+	e.time = t
+	return e
+}
+
+func TestEventTimeOverridableWithTime(t *testing.T) {
+	e := fieldsEvent{flag: "ping", time: time.Unix(0, 0)}
+	backdated := time.Unix(1000, 0)
+
+	updated := e.WithTime(backdated).(fieldsEvent)
+
+	if !updated.Timestamp().Equal(backdated) {
+		t.Fatalf("expected WithTime to override Timestamp(), got %v", updated.Timestamp())
+	}
+}
+
+func TestEventFieldsExposesStructuredData(t *testing.T) {
+	e := fieldsEvent{flag: "ping", time: time.Now()}
+
+	fields := e.Fields()
+	if fields["key"] != "value" {
+		t.Fatalf("expected Fields() to expose structured data, got %v", fields)
+	}
+}
@@ -0,0 +1,271 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FlagFatal is the flag used for events raised by SyncFatalf.
+const FlagFatal Flag = "fatal"
+
+// formattedEvent is the event Logger's formatted trigger methods (Infof,
+// Errorf, SyncFatalf) write.
+type formattedEvent struct {
+	EventFlag  Flag
+	Time       time.Time
+	Message    string
+	CallerSite string
+}
+
+// Flag implements Event.
+func (e formattedEvent) Flag() Flag { return e.EventFlag }
+
+// Timestamp implements Event.
+func (e formattedEvent) Timestamp() time.Time { return e.Time }
+
+// String implements fmt.Stringer.
+func (e formattedEvent) String() string { return e.Message }
+
+// Caller implements EventCaller.
+func (e formattedEvent) Caller() string { return e.CallerSite }
+
+// WithCaller implements EventCallerSettable.
+func (e formattedEvent) WithCaller(caller string) Event {
+	e.CallerSite = caller
+	return e
+}
+
+// New returns a new logger scoped to the given flags, with no writers
+// attached yet; chain WithWriter to add one or more.
+func New(flags *FlagSet) *Logger {
+	return &Logger{
+		flags:   flags,
+		workers: map[Flag][]*Worker{},
+		hooks:   NewHookSet(),
+	}
+}
+
+// All returns a new logger with every flag enabled.
+func All() *Logger {
+	return New(NewFlagSetAll())
+}
+
+// None returns a new logger with no flags enabled.
+func None() *Logger {
+	return New(NewFlagSetNone())
+}
+
+// NewFromEnv returns a new logger configured from the environment.
+func NewFromEnv() *Logger {
+	return New(NewFlagSetFromEnv())
+}
+
+// Logger dispatches events of enabled flags to a set of writers, each on
+// its own per-flag Worker, so a slow writer can't block another. Hooks
+// registered via AddHook are fired synchronously, on the caller's
+// goroutine, before that async dispatch happens.
+type Logger struct {
+	mu      sync.Mutex
+	flags   *FlagSet
+	writers []Writer
+	workers map[Flag][]*Worker
+	hooks   *HookSet
+	caller  bool
+
+	overflowPolicy OverflowPolicy
+	sampleRate     uint64
+	statsInterval  time.Duration
+	drainTimeout   time.Duration
+}
+
+// Caller returns whether automatic caller-site capture is enabled.
+func (l *Logger) Caller() bool {
+	return l.caller
+}
+
+// WithCaller toggles automatic caller-site capture on the trigger methods
+// (Infof, Errorf, SyncFatalf, Write). When enabled, the call site is
+// captured at write-time, via runtime.Caller, on the goroutine that called
+// the trigger method, since Worker.Process later runs on a different
+// goroutine where that call site is no longer available. Returns the
+// logger for chaining.
+func (l *Logger) WithCaller(caller bool) *Logger {
+	l.caller = caller
+	return l
+}
+
+// Flags returns the logger's flag set.
+func (l *Logger) Flags() *FlagSet {
+	return l.flags
+}
+
+// WithWriter adds a writer that every enabled event is dispatched to.
+// Returns the logger for chaining.
+func (l *Logger) WithWriter(writer Writer) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writers = append(l.writers, writer)
+	return l
+}
+
+// WithOverflowPolicy sets the OverflowPolicy applied to every Worker this
+// logger starts, e.g. OverflowPolicyDropOldest so a hot path like the ping
+// example's 500ms poll loop can't block on a stalled writer. Must be
+// called before the first event of a given flag is written, since workers
+// are started lazily per flag. Returns the logger for chaining.
+func (l *Logger) WithOverflowPolicy(policy OverflowPolicy) *Logger {
+	l.overflowPolicy = policy
+	return l
+}
+
+// WithSampleRate sets the SampleRate (see OverflowPolicySampleEveryN)
+// applied to every Worker this logger starts. Returns the logger for
+// chaining.
+func (l *Logger) WithSampleRate(rate uint64) *Logger {
+	l.sampleRate = rate
+	return l
+}
+
+// WithStatsInterval sets the StatsInterval applied to every Worker this
+// logger starts, causing each to periodically emit a WorkerStats event via
+// this logger. Returns the logger for chaining.
+func (l *Logger) WithStatsInterval(d time.Duration) *Logger {
+	l.statsInterval = d
+	return l
+}
+
+// WithDrainTimeout sets how long Close gives each Worker to drain its
+// remaining queued events before giving up. Zero (the default) closes
+// workers immediately, dropping anything still queued. Returns the logger
+// for chaining.
+func (l *Logger) WithDrainTimeout(d time.Duration) *Logger {
+	l.drainTimeout = d
+	return l
+}
+
+// AddHook registers a hook. Fire is called synchronously, in flag-match
+// order, for every event whose flag the hook subscribes to, before that
+// event is handed off to the async writer Workers; see Hook for why that
+// matters.
+func (l *Logger) AddHook(hook Hook) {
+	l.hooks.Add(hook)
+}
+
+// Write fires any hooks registered for the event's flag, then (if the flag
+// is enabled) dispatches the event to every writer's Worker. The first hook
+// error, if any, is returned; dispatch to writers still happens regardless.
+// If caller capture is enabled and the event implements
+// EventCallerSettable, its caller site is attached before dispatch.
+func (l *Logger) Write(e Event) error {
+	if l.caller {
+		if typed, ok := e.(EventCallerSettable); ok {
+			e = typed.WithCaller(CaptureCaller(1))
+		}
+	}
+	return l.dispatch(e)
+}
+
+// Infof writes a formatted Info event, attaching the caller site if caller
+// capture is enabled.
+func (l *Logger) Infof(format string, args ...interface{}) error {
+	return l.dispatch(l.formatf(Info, format, args...))
+}
+
+// Errorf writes a formatted Error event, attaching the caller site if
+// caller capture is enabled.
+func (l *Logger) Errorf(format string, args ...interface{}) error {
+	return l.dispatch(l.formatf(Error, format, args...))
+}
+
+// formatf builds a formattedEvent for the given flag, capturing the caller
+// of the trigger method (Infof/Errorf/SyncFatalf) that called formatf, if
+// caller capture is enabled.
+func (l *Logger) formatf(flag Flag, format string, args ...interface{}) formattedEvent {
+	e := formattedEvent{
+		EventFlag: flag,
+		Time:      time.Now().UTC(),
+		Message:   fmt.Sprintf(format, args...),
+	}
+	if l.caller {
+		e.CallerSite = CaptureCaller(2)
+	}
+	return e
+}
+
+// dispatch fires any hooks registered for the event's flag, then (if the
+// flag is enabled) dispatches the event to every writer's Worker.
+func (l *Logger) dispatch(e Event) error {
+	err := l.hooks.Fire(e)
+	if !l.flags.IsEnabled(e.Flag()) {
+		return err
+	}
+	for _, worker := range l.workersFor(e.Flag()) {
+		worker.Enqueue(e)
+	}
+	return err
+}
+
+// workersFor lazily starts one Worker per writer for a given flag, the
+// first time that flag is written, and reuses them after that.
+func (l *Logger) workersFor(flag Flag) []*Worker {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if workers, ok := l.workers[flag]; ok {
+		return workers
+	}
+
+	workers := make([]*Worker, 0, len(l.writers))
+	for _, writer := range l.writers {
+		worker := NewWorker(l, writerListener(writer))
+		worker.OverflowPolicy = l.overflowPolicy
+		if l.sampleRate > 0 {
+			worker.SampleRate = l.sampleRate
+		}
+		worker.StatsInterval = l.statsInterval
+		worker.Start()
+		workers = append(workers, worker)
+	}
+	l.workers[flag] = workers
+	return workers
+}
+
+// writerListener adapts a Writer into the Listener a Worker expects.
+func writerListener(writer Writer) Listener {
+	return func(e Event) {
+		writer.Write(e)
+	}
+}
+
+// SyncFatalf fires any hooks registered for FlagFatal, then synchronously
+// writes a fatal event directly to every writer, bypassing the async Worker
+// queues entirely. It exists for use from recover() paths, like
+// Worker.Process's, where the goroutine that would otherwise enqueue the
+// event may be about to exit — exactly the case a hook-based integration
+// (e.g. Sentry) most needs to observe, so it must not skip hook dispatch.
+func (l *Logger) SyncFatalf(format string, args ...interface{}) {
+	e := l.formatf(FlagFatal, format, args...)
+	l.hooks.Fire(e)
+
+	l.mu.Lock()
+	writers := append([]Writer{}, l.writers...)
+	l.mu.Unlock()
+
+	for _, writer := range writers {
+		writer.WriteError(e)
+	}
+}
+
+// Close stops every worker started across every flag, giving each up to
+// DrainTimeout (see WithDrainTimeout) to drain its remaining queued events.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, workers := range l.workers {
+		for _, worker := range workers {
+			worker.CloseTimeout(l.drainTimeout)
+		}
+	}
+	return nil
+}
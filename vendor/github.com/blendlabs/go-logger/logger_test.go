@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+type recordingWriter struct {
+	written []Event
+}
+
+func (w *recordingWriter) Write(e Event) error {
+	w.written = append(w.written, e)
+	return nil
+}
+
+func (w *recordingWriter) WriteError(e Event) error {
+	return w.Write(e)
+}
+
+// TestLoggerWithOverflowPolicyAppliesToWorkers guards against a prior bug
+// where WithOverflowPolicy/WithSampleRate/WithStatsInterval had no effect:
+// workersFor always built workers via plain NewWorker, ignoring every
+// Logger-level knob.
+func TestLoggerWithOverflowPolicyAppliesToWorkers(t *testing.T) {
+	l := All().
+		WithWriter(&recordingWriter{}).
+		WithOverflowPolicy(OverflowPolicyDropOldest).
+		WithSampleRate(3).
+		WithStatsInterval(time.Hour)
+
+	workers := l.workersFor("ping")
+	if len(workers) != 1 {
+		t.Fatalf("expected one worker per writer, got %d", len(workers))
+	}
+	w := workers[0]
+	if w.OverflowPolicy != OverflowPolicyDropOldest {
+		t.Fatalf("expected OverflowPolicyDropOldest to reach the worker, got %v", w.OverflowPolicy)
+	}
+	if w.SampleRate != 3 {
+		t.Fatalf("expected SampleRate 3 to reach the worker, got %d", w.SampleRate)
+	}
+	if w.StatsInterval != time.Hour {
+		t.Fatalf("expected StatsInterval to reach the worker, got %v", w.StatsInterval)
+	}
+}
+
+// TestLoggerCloseUsesDrainTimeout guards against a prior bug where Close
+// always called Worker.Close (no drain), ignoring WithDrainTimeout
+// entirely, so queued-but-unprocessed events were silently dropped on
+// shutdown even when the caller asked for time to drain them.
+func TestLoggerCloseUsesDrainTimeout(t *testing.T) {
+	writer := &recordingWriter{}
+	l := All().WithWriter(writer).WithDrainTimeout(time.Second)
+
+	workers := l.workersFor("ping")
+	w := workers[0]
+	w.Stop() // stop consuming so the queued event below survives to Close.
+	w.Work <- testEvent("ping")
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(writer.written) != 1 {
+		t.Fatalf("expected Close to drain the queued event via DrainTimeout, got %d writes", len(writer.written))
+	}
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// OverflowPolicy governs what a Worker does when its Work queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowPolicyBlock blocks the caller until there is room in the
+	// queue. This is the default, and matches the prior (implicit) behavior
+	// of a plain buffered channel send.
+	OverflowPolicyBlock OverflowPolicy = iota
+	// OverflowPolicyDropNewest drops the event being enqueued, leaving the
+	// queue unchanged.
+	OverflowPolicyDropNewest
+	// OverflowPolicyDropOldest drops the oldest queued event to make room
+	// for the event being enqueued.
+	OverflowPolicyDropOldest
+	// OverflowPolicySampleEveryN, while the queue is full, gives every Nth
+	// overflowing event one more non-blocking attempt to enqueue and drops
+	// the rest; that Nth event is dropped too if the queue is still full. N
+	// is the Worker's SampleRate.
+	OverflowPolicySampleEveryN
+)
+
+// String implements fmt.Stringer.
+func (op OverflowPolicy) String() string {
+	switch op {
+	case OverflowPolicyBlock:
+		return "block"
+	case OverflowPolicyDropNewest:
+		return "drop-newest"
+	case OverflowPolicyDropOldest:
+		return "drop-oldest"
+	case OverflowPolicySampleEveryN:
+		return "sample-every-n"
+	default:
+		return fmt.Sprintf("overflow-policy(%d)", int(op))
+	}
+}
+
+// FlagLoggerSelf is the flag used for synthetic events a Worker emits about
+// its own health, e.g. periodic queue depth/drop stats.
+const FlagLoggerSelf Flag = "logger.self"
+
+// WorkerStats is a point-in-time snapshot of a Worker's queue counters,
+// returned by Worker.Stats() and periodically emitted as a FlagLoggerSelf
+// event.
+type WorkerStats struct {
+	Dropped       uint64
+	Enqueued      uint64
+	Processed     uint64
+	MaxQueueDepth int
+	Time          time.Time
+}
+
+// Flag implements Event.
+func (ws WorkerStats) Flag() Flag {
+	return FlagLoggerSelf
+}
+
+// Timestamp implements Event.
+func (ws WorkerStats) Timestamp() time.Time {
+	return ws.Time
+}
+
+// Fields implements EventFields.
+func (ws WorkerStats) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"dropped":         ws.Dropped,
+		"enqueued":        ws.Enqueued,
+		"processed":       ws.Processed,
+		"max_queue_depth": ws.MaxQueueDepth,
+	}
+}
@@ -0,0 +1,364 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRollingFileMaxSizeBytes is the default size, in bytes, at which
+	// a rolling file writer rotates its active log file.
+	DefaultRollingFileMaxSizeBytes int64 = 100 * 1024 * 1024 // 100mb
+
+	// DefaultRollingFilePruneInterval is the default interval at which a
+	// rolling file writer prunes backups older than MaxAge or in excess of
+	// MaxBackups.
+	DefaultRollingFilePruneInterval = time.Hour
+
+	rollingFileBackupTimeFormat = "20060102T150405"
+)
+
+// NewRollingFileWriter returns a new rolling file writer for the given base
+// path, e.g. "/var/log/myapp.log". Rotated backups are written alongside it
+// as "myapp-<timestamp>.log.gz" (or uncompressed, if WithCompress(false)).
+func NewRollingFileWriter(path string) *RollingFileWriter {
+	rw := &RollingFileWriter{
+		path:       path,
+		maxSize:    DefaultRollingFileMaxSizeBytes,
+		compress:   true,
+		pruneEvery: DefaultRollingFilePruneInterval,
+		bufferPool: NewBufferPool(DefaultBufferPoolSize),
+	}
+	return rw
+}
+
+// RollingFileWriter is a Writer that wraps a file on disk, rotating it by
+// size and gzip-compressing rotated backups, comparable to lumberjack. Age
+// (WithMaxAge) only governs pruning of rotated backups; the active file is
+// not rotated by age. It wraps the existing InterlockedWriter for safe concurrent
+// writes and reuses the common BufferPool.
+type RollingFileWriter struct {
+	sync.Mutex
+
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+	localTime  bool
+
+	bufferPool *BufferPool
+
+	file        *os.File
+	output      io.Writer
+	currentSize int64
+
+	pruneEvery time.Duration
+	pruneAbort chan bool
+}
+
+// WithMaxSize sets the size, in bytes, at which the active log file is
+// rotated.
+func (rw *RollingFileWriter) WithMaxSize(maxSizeBytes int64) *RollingFileWriter {
+	rw.maxSize = maxSizeBytes
+	return rw
+}
+
+// WithMaxAge sets the maximum age a rotated backup is kept before being
+// pruned. Zero (the default) disables age-based pruning.
+func (rw *RollingFileWriter) WithMaxAge(maxAge time.Duration) *RollingFileWriter {
+	rw.maxAge = maxAge
+	return rw
+}
+
+// WithMaxBackups sets the maximum number of rotated backups kept. Zero (the
+// default) disables count-based pruning.
+func (rw *RollingFileWriter) WithMaxBackups(maxBackups int) *RollingFileWriter {
+	rw.maxBackups = maxBackups
+	return rw
+}
+
+// WithCompress sets whether rotated backups are gzip-compressed.
+func (rw *RollingFileWriter) WithCompress(compress bool) *RollingFileWriter {
+	rw.compress = compress
+	return rw
+}
+
+// WithLocalTime sets whether backup filenames use local time instead of UTC.
+func (rw *RollingFileWriter) WithLocalTime(localTime bool) *RollingFileWriter {
+	rw.localTime = localTime
+	return rw
+}
+
+// Write writes an event to the active log file, rotating first if the
+// event would push it past MaxSize.
+func (rw *RollingFileWriter) Write(e Event) error {
+	return rw.write(e)
+}
+
+// WriteError writes an event to the active log file; RollingFileWriter does
+// not distinguish an error stream from the primary one.
+func (rw *RollingFileWriter) WriteError(e Event) error {
+	return rw.write(e)
+}
+
+func (rw *RollingFileWriter) write(e Event) error {
+	rw.Lock()
+	defer rw.Unlock()
+
+	if rw.file == nil {
+		if err := rw.openCurrentFile(); err != nil {
+			return err
+		}
+	}
+
+	buf := rw.bufferPool.Get()
+	defer rw.bufferPool.Put(buf)
+	writeEventLine(buf, e)
+
+	if rw.maxSize > 0 && rw.currentSize+int64(buf.Len()) > rw.maxSize {
+		if err := rw.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := buf.WriteTo(rw.output)
+	rw.currentSize += int64(n)
+	return err
+}
+
+// writeEventLine renders a minimal, dependency-free line for a single event;
+// it exists so RollingFileWriter doesn't have to import TextWriter's private
+// formatting state.
+func writeEventLine(buf *bytes.Buffer, e Event) {
+	buf.WriteString(e.Timestamp().UTC().Format(DefaultTextTimeFormat))
+	buf.WriteRune(RuneSpace)
+	buf.WriteString(fmt.Sprintf("[%s]", e.Flag()))
+	if typed, isTyped := e.(TextWritable); isTyped {
+		buf.WriteRune(RuneSpace)
+		typed.WriteText(noColorTextFormatter{}, buf)
+	} else if typed, isTyped := e.(fmt.Stringer); isTyped {
+		buf.WriteRune(RuneSpace)
+		buf.WriteString(typed.String())
+	}
+	buf.WriteRune(RuneNewline)
+}
+
+// noColorTextFormatter is a plain TextFormatter with colorization disabled,
+// used to render TextWritable events to a file where ANSI codes don't belong.
+type noColorTextFormatter struct{}
+
+func (noColorTextFormatter) Colorize(value string, color AnsiColor) string {
+	return value
+}
+
+func (noColorTextFormatter) ColorizeStatusCode(code int) string {
+	return fmt.Sprintf("%d", code)
+}
+
+func (noColorTextFormatter) ColorizeByStatusCode(code int, value string) string {
+	return value
+}
+
+func (rw *RollingFileWriter) openCurrentFile() error {
+	if err := os.MkdirAll(filepath.Dir(rw.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(rw.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	rw.file = file
+	rw.output = NewInterlockedWriter(file)
+	rw.currentSize = info.Size()
+	return nil
+}
+
+// rotate closes the active file, renames it aside, reopens a fresh active
+// file, and (if enabled) gzip-compresses the rotated backup in place. The
+// active file is always left closed with rw.file cleared, even on error, so
+// a failed rotation doesn't wedge the writer: the next write() sees a nil
+// rw.file and retries openCurrentFile rather than writing against an fd
+// that's already been closed out from under it.
+func (rw *RollingFileWriter) rotate() error {
+	if rw.file != nil {
+		rw.file.Close()
+		rw.file = nil
+		rw.output = nil
+	}
+
+	backupPath := rw.backupPath()
+	if _, err := os.Stat(rw.path); err == nil {
+		if err := os.Rename(rw.path, backupPath); err != nil {
+			return err
+		}
+		if rw.compress {
+			if err := gzipFile(backupPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return rw.openCurrentFile()
+}
+
+// backupPath returns a path for the next rotated backup, guaranteed not to
+// already exist. The base name only carries second resolution, so a burst
+// of rotations within the same second would otherwise collide and
+// os.Rename would silently clobber the earlier backup; on collision, a
+// "-N" counter is appended until the path is free (and, when compressing,
+// until its ".gz" counterpart is free too, since gzipFile would otherwise
+// truncate the earlier compressed backup).
+func (rw *RollingFileWriter) backupPath() string {
+	now := time.Now().UTC()
+	if rw.localTime {
+		now = time.Now()
+	}
+	ext := filepath.Ext(rw.path)
+	base := strings.TrimSuffix(rw.path, ext)
+	stamped := fmt.Sprintf("%s-%s%s", base, now.Format(rollingFileBackupTimeFormat), ext)
+
+	path := stamped
+	for n := 1; ; n++ {
+		_, err := os.Stat(path)
+		_, gzErr := os.Stat(path + ".gz")
+		if err != nil && (!rw.compress || gzErr != nil) {
+			return path
+		}
+		path = fmt.Sprintf("%s-%s-%d%s", base, now.Format(rollingFileBackupTimeFormat), n, ext)
+	}
+}
+
+func gzipFile(path string) error {
+	raw, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer raw.Close()
+
+	gzPath := path + ".gz"
+	gzFile, err := os.Create(gzPath)
+	if err != nil {
+		return err
+	}
+	defer gzFile.Close()
+
+	gzWriter := gzip.NewWriter(gzFile)
+	if _, err := io.Copy(gzWriter, raw); err != nil {
+		return err
+	}
+	if err := gzWriter.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// StartPruning starts a background goroutine that prunes backups older than
+// MaxAge, or in excess of MaxBackups, every DefaultRollingFilePruneInterval.
+func (rw *RollingFileWriter) StartPruning() {
+	rw.pruneAbort = make(chan bool)
+	ticker := time.NewTicker(rw.pruneEvery)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				rw.pruneBackups()
+			case <-rw.pruneAbort:
+				return
+			}
+		}
+	}()
+}
+
+func (rw *RollingFileWriter) pruneBackups() {
+	backups, err := rw.listBackups()
+	if err != nil {
+		return
+	}
+
+	if rw.maxAge > 0 {
+		cutoff := time.Now().Add(-rw.maxAge)
+		var kept []backupFile
+		for _, b := range backups {
+			if b.modTime.Before(cutoff) {
+				os.Remove(b.path)
+			} else {
+				kept = append(kept, b)
+			}
+		}
+		backups = kept
+	}
+
+	if rw.maxBackups > 0 && len(backups) > rw.maxBackups {
+		sort.Slice(backups, func(i, j int) bool {
+			return backups[i].modTime.After(backups[j].modTime)
+		})
+		for _, b := range backups[rw.maxBackups:] {
+			os.Remove(b.path)
+		}
+	}
+}
+
+type backupFile struct {
+	path    string
+	modTime time.Time
+}
+
+func (rw *RollingFileWriter) listBackups() ([]backupFile, error) {
+	dir := filepath.Dir(rw.path)
+	ext := filepath.Ext(rw.path)
+	base := strings.TrimSuffix(filepath.Base(rw.path), ext)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if !strings.HasPrefix(name, base+"-") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{
+			path:    filepath.Join(dir, name),
+			modTime: info.ModTime(),
+		})
+	}
+	return backups, nil
+}
+
+// Cleanup stops the background pruning goroutine, if running, and closes
+// the active file. It should be called during shutdown.
+func (rw *RollingFileWriter) Cleanup() error {
+	if rw.pruneAbort != nil {
+		rw.pruneAbort <- true
+	}
+
+	rw.Lock()
+	defer rw.Unlock()
+	if rw.file != nil {
+		return rw.file.Close()
+	}
+	return nil
+}
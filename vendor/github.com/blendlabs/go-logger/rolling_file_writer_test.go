@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRollingFileWriterRotatesOnMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rw := NewRollingFileWriter(path).WithMaxSize(1).WithCompress(false)
+
+	if err := rw.Write(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rw.Write(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) < 2 {
+		t.Fatalf("expected a rotated backup alongside the active file, got %d entries", len(entries))
+	}
+}
+
+// TestRollingFileWriterBurstRotationsDontClobber guards against a prior bug
+// where backupPath() only carried second resolution: rotating several
+// times within the same wall-clock second produced the same backup path,
+// and os.Rename silently overwrote the earlier backup, losing its data.
+func TestRollingFileWriterBurstRotationsDontClobber(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rw := NewRollingFileWriter(path).WithMaxSize(1).WithCompress(false)
+
+	const rotations = 5
+	for i := 0; i < rotations; i++ {
+		if err := rw.Write(testEvent("ping")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var backups int
+	for _, entry := range entries {
+		if entry.Name() != "app.log" {
+			backups++
+		}
+	}
+	if backups != rotations {
+		t.Fatalf("expected %d surviving backups from %d rotations, got %d", rotations, rotations, backups)
+	}
+}
+
+func TestRollingFileWriterCompressesBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rw := NewRollingFileWriter(path).WithMaxSize(1).WithCompress(true)
+
+	if err := rw.Write(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rw.Write(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var sawGzBackup bool
+	for _, entry := range entries {
+		if entry.Name() != "app.log" && filepath.Ext(entry.Name()) == ".gz" {
+			sawGzBackup = true
+		}
+	}
+	if !sawGzBackup {
+		t.Fatalf("expected a gzip-compressed backup, got entries: %v", entries)
+	}
+}
+
+// TestRollingFileWriterRotateSelfHealsOnError guards against a prior bug
+// where a failed rotate() left rw.file non-nil but closed, permanently
+// wedging the writer: every later write() would see a non-nil rw.file,
+// skip openCurrentFile, and silently fail against the dead fd.
+func TestRollingFileWriterRotateSelfHealsOnError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rw := NewRollingFileWriter(path).WithCompress(false)
+	if err := rw.openCurrentFile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Point rw.path somewhere openCurrentFile can never succeed (a path
+	// component that's a plain file, not a directory), so rotate()'s
+	// reopen fails.
+	blocked := filepath.Join(dir, "not-a-dir")
+	if err := os.WriteFile(blocked, []byte("x"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rw.path = filepath.Join(blocked, "app.log")
+
+	if err := rw.rotate(); err == nil {
+		t.Fatal("expected rotate to fail when the active file can't be reopened")
+	}
+	if rw.file != nil {
+		t.Fatal("expected rw.file to be nil after a failed rotate so the next write() self-heals")
+	}
+
+	// Point back at a writable location; write() should reopen rather than
+	// stay wedged.
+	rw.path = filepath.Join(dir, "app2.log")
+	if err := rw.write(testEvent("ping")); err != nil {
+		t.Fatalf("expected write to self-heal after a failed rotation, got: %v", err)
+	}
+}
+
+func TestRollingFileWriterPruneBackupsRespectsMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rw := NewRollingFileWriter(path).WithMaxBackups(1)
+
+	for i := 0; i < 3; i++ {
+		if err := os.WriteFile(filepath.Join(dir, "app-"+string(rune('a'+i))+".log"), []byte("x"), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	rw.pruneBackups()
+
+	backups, err := rw.listBackups()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("expected pruning down to 1 backup, got %d", len(backups))
+	}
+}
+
+func TestRollingFileWriterCleanupClosesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rw := NewRollingFileWriter(path)
+	if err := rw.Write(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rw.Cleanup(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
@@ -256,13 +257,44 @@ func (wr *TextWriter) write(output io.Writer, e Event) error {
 	}
 	buf.WriteRune(RuneSpace)
 
+	if typed, isTyped := e.(EventCaller); isTyped {
+		if caller := typed.Caller(); len(caller) > 0 {
+			buf.WriteString(wr.Colorize(caller, ColorGray))
+			buf.WriteRune(RuneSpace)
+		}
+	}
+
 	if typed, isTyped := e.(TextWritable); isTyped {
 		typed.WriteText(wr, buf)
 	} else if typed, isTyped := e.(fmt.Stringer); isTyped {
 		buf.WriteString(typed.String())
 	}
 
+	if typed, isTyped := e.(EventFields); isTyped {
+		wr.writeFields(buf, typed.Fields())
+	}
+
 	buf.WriteRune(RuneNewline)
 	_, err := buf.WriteTo(output)
 	return err
 }
+
+// writeFields appends `key=value` pairs for an event's Fields(), sorted by
+// key so repeated runs of the same event produce identical output.
+func (wr *TextWriter) writeFields(buf *bytes.Buffer, fields map[string]interface{}) {
+	if len(fields) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		buf.WriteRune(RuneSpace)
+		buf.WriteString(wr.Colorize(key, ColorGray))
+		buf.WriteRune('=')
+		buf.WriteString(fmt.Sprintf("%v", fields[key]))
+	}
+}
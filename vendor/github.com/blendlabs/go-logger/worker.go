@@ -1,18 +1,30 @@
 package logger
 
+import (
+	"sync/atomic"
+	"time"
+)
+
 const (
 	// DefaultWorkerQueueDepth is the default depth per listener to queue work.
 	DefaultWorkerQueueDepth = 1 << 20
+
+	// DefaultWorkerStatsInterval is a stats interval value for use with
+	// WithStatsInterval. NewWorker does not apply it: StatsInterval starts at
+	// zero, which disables FlagLoggerSelf stats events.
+	DefaultWorkerStatsInterval = 30 * time.Second
 )
 
 // NewWorker returns a new worker.
 func NewWorker(parent *Logger, listener Listener) *Worker {
 	return &Worker{
-		Parent:   parent,
-		Listener: listener,
-		Work:     make(chan Event, DefaultWorkerQueueDepth),
-		Abort:    make(chan bool),
-		Aborted:  make(chan bool),
+		Parent:         parent,
+		Listener:       listener,
+		Work:           make(chan Event, DefaultWorkerQueueDepth),
+		Abort:          make(chan bool),
+		Aborted:        make(chan bool),
+		OverflowPolicy: OverflowPolicyBlock,
+		SampleRate:     1,
 	}
 }
 
@@ -25,11 +37,33 @@ type Worker struct {
 	Drained   chan bool
 	Work      chan Event
 	IsRunning bool
+
+	// OverflowPolicy governs what happens when Work is full.
+	OverflowPolicy OverflowPolicy
+	// SampleRate is the N in OverflowPolicySampleEveryN; while the queue is
+	// full, every Nth overflowing event gets one more non-blocking attempt to
+	// enqueue and the rest are dropped. Defaults to 1, under which every
+	// overflowing event gets that attempt.
+	SampleRate uint64
+	// StatsInterval, if non-zero, causes the worker to periodically emit a
+	// WorkerStats event to Parent via a FlagLoggerSelf event.
+	StatsInterval time.Duration
+	statsAbort    chan bool
+
+	dropped       uint64
+	enqueued      uint64
+	processed     uint64
+	overflowCount uint64
+	maxQueueDepth int64
 }
 
 // Start starts the worker.
 func (w *Worker) Start() {
 	go w.ProcessLoop()
+	if w.StatsInterval > 0 {
+		w.statsAbort = make(chan bool)
+		go w.statsLoop()
+	}
 }
 
 // ProcessLoop is the for/select loop.
@@ -48,9 +82,95 @@ func (w *Worker) ProcessLoop() {
 	}
 }
 
+func (w *Worker) statsLoop() {
+	ticker := time.NewTicker(w.StatsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if w.Parent != nil {
+				w.Parent.Write(w.Stats())
+			}
+		case <-w.statsAbort:
+			return
+		}
+	}
+}
+
+// Enqueue pushes an event onto the Work queue, honoring OverflowPolicy when
+// the queue is full. It replaces a bare `w.Work <- e` send, which blocks
+// indefinitely once DefaultWorkerQueueDepth is exhausted.
+func (w *Worker) Enqueue(e Event) {
+	if w.OverflowPolicy == OverflowPolicyBlock {
+		w.Work <- e
+		w.trackEnqueue()
+		return
+	}
+
+	select {
+	case w.Work <- e:
+		w.trackEnqueue()
+		return
+	default:
+	}
+
+	switch w.OverflowPolicy {
+	case OverflowPolicyDropNewest:
+		atomic.AddUint64(&w.dropped, 1)
+	case OverflowPolicyDropOldest:
+		select {
+		case <-w.Work:
+			atomic.AddUint64(&w.dropped, 1)
+		default:
+		}
+		select {
+		case w.Work <- e:
+			w.trackEnqueue()
+		default:
+			atomic.AddUint64(&w.dropped, 1)
+		}
+	case OverflowPolicySampleEveryN:
+		rate := w.SampleRate
+		if rate == 0 {
+			rate = 1
+		}
+		if atomic.AddUint64(&w.overflowCount, 1)%rate == 0 {
+			select {
+			case w.Work <- e:
+				w.trackEnqueue()
+			default:
+				atomic.AddUint64(&w.dropped, 1)
+			}
+		} else {
+			atomic.AddUint64(&w.dropped, 1)
+		}
+	default:
+		atomic.AddUint64(&w.dropped, 1)
+	}
+}
+
+func (w *Worker) trackEnqueue() {
+	atomic.AddUint64(&w.enqueued, 1)
+	if depth := int64(len(w.Work)); depth > atomic.LoadInt64(&w.maxQueueDepth) {
+		atomic.StoreInt64(&w.maxQueueDepth, depth)
+	}
+}
+
+// Stats returns a point-in-time snapshot of the worker's queue counters.
+func (w *Worker) Stats() WorkerStats {
+	return WorkerStats{
+		Dropped:       atomic.LoadUint64(&w.dropped),
+		Enqueued:      atomic.LoadUint64(&w.enqueued),
+		Processed:     atomic.LoadUint64(&w.processed),
+		MaxQueueDepth: int(atomic.LoadInt64(&w.maxQueueDepth)),
+		Time:          time.Now().UTC(),
+	}
+}
+
 // Process calls the listener for an event.
 func (w *Worker) Process(e Event) {
 	defer func() {
+		atomic.AddUint64(&w.processed, 1)
 		if r := recover(); r != nil {
 			if w.Parent != nil {
 				w.Parent.SyncFatalf("%v", r)
@@ -65,6 +185,9 @@ func (w *Worker) Stop() {
 	if !w.IsRunning {
 		return
 	}
+	if w.statsAbort != nil {
+		w.statsAbort <- true
+	}
 	w.Abort <- true
 	<-w.Aborted
 }
@@ -77,9 +200,27 @@ func (w *Worker) Drain() {
 	}
 }
 
-// Close closes the worker.
-func (w *Worker) Close() error {
+// DrainTimeout stops the worker and synchronously processes any remaining
+// work, but gives up after `d` so a slow listener can't hang shutdown
+// forever. Events still queued when the timeout fires are left unprocessed.
+func (w *Worker) DrainTimeout(d time.Duration) {
 	w.Stop()
+	deadline := time.Now().Add(d)
+	for len(w.Work) > 0 && time.Now().Before(deadline) {
+		w.Process(<-w.Work)
+	}
+}
+
+// Close stops the worker and closes its channels immediately, leaving any
+// remaining queued work unprocessed. Equivalent to CloseTimeout(0).
+func (w *Worker) Close() error {
+	return w.CloseTimeout(0)
+}
+
+// CloseTimeout stops the worker, giving it up to d to drain remaining
+// queued work (see DrainTimeout), then closes its channels.
+func (w *Worker) CloseTimeout(d time.Duration) error {
+	w.DrainTimeout(d)
 	close(w.Work)
 	close(w.Abort)
 	close(w.Aborted)
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func testEvent(flag Flag) Event {
+	return formattedEvent{EventFlag: flag, Time: time.Now().UTC(), Message: "test"}
+}
+
+func newTestWorker(depth int, policy OverflowPolicy) *Worker {
+	return &Worker{
+		Work:           make(chan Event, depth),
+		Abort:          make(chan bool),
+		Aborted:        make(chan bool),
+		OverflowPolicy: policy,
+		SampleRate:     1,
+	}
+}
+
+func TestWorkerEnqueueDropNewest(t *testing.T) {
+	w := newTestWorker(2, OverflowPolicyDropNewest)
+
+	w.Enqueue(testEvent("a"))
+	w.Enqueue(testEvent("b"))
+	w.Enqueue(testEvent("c")) // queue is full; should be dropped
+
+	if len(w.Work) != 2 {
+		t.Fatalf("expected queue to stay at depth 2, got %d", len(w.Work))
+	}
+	stats := w.Stats()
+	if stats.Dropped != 1 {
+		t.Fatalf("expected 1 dropped event, got %d", stats.Dropped)
+	}
+	if stats.Enqueued != 2 {
+		t.Fatalf("expected 2 enqueued events, got %d", stats.Enqueued)
+	}
+}
+
+func TestWorkerEnqueueDropOldest(t *testing.T) {
+	w := newTestWorker(2, OverflowPolicyDropOldest)
+
+	w.Enqueue(testEvent("a"))
+	w.Enqueue(testEvent("b"))
+	w.Enqueue(testEvent("c")) // should evict "a" and enqueue "c"
+
+	if len(w.Work) != 2 {
+		t.Fatalf("expected queue to stay at depth 2, got %d", len(w.Work))
+	}
+	first := <-w.Work
+	if first.Flag() != "b" {
+		t.Fatalf("expected oldest surviving event to be %q, got %q", "b", first.Flag())
+	}
+}
+
+func TestWorkerEnqueueSampleEveryN(t *testing.T) {
+	w := newTestWorker(1, OverflowPolicySampleEveryN)
+	w.SampleRate = 2
+
+	w.Enqueue(testEvent("a")) // fills the queue
+	w.Enqueue(testEvent("b")) // overflow #1, not a multiple of 2: dropped
+	w.Enqueue(testEvent("c")) // overflow #2, multiple of 2: queue full, dropped anyway
+
+	stats := w.Stats()
+	if stats.Dropped != 2 {
+		t.Fatalf("expected 2 dropped events, got %d", stats.Dropped)
+	}
+}
+
+func TestWorkerStatsTracksMaxQueueDepth(t *testing.T) {
+	w := newTestWorker(4, OverflowPolicyBlock)
+
+	w.Enqueue(testEvent("a"))
+	w.Enqueue(testEvent("b"))
+	<-w.Work
+	w.Enqueue(testEvent("c"))
+
+	if got := w.Stats().MaxQueueDepth; got != 2 {
+		t.Fatalf("expected max queue depth of 2, got %d", got)
+	}
+}
+
+func TestLoggerDispatchUsesWorkerEnqueue(t *testing.T) {
+	l := New(NewFlagSetAll())
+	w := newTestWorker(1, OverflowPolicyDropNewest)
+	l.workers["ping"] = []*Worker{w}
+
+	if err := l.dispatch(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := l.dispatch(testEvent("ping")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats := w.Stats(); stats.Enqueued != 1 || stats.Dropped != 1 {
+		t.Fatalf("expected dispatch to route through Worker.Enqueue (enqueued=1, dropped=1), got enqueued=%d dropped=%d", stats.Enqueued, stats.Dropped)
+	}
+}